@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"github.com/jokruger/pwgen/internal/generator"
 	"github.com/spf13/cobra"
 )
 
@@ -20,30 +19,10 @@ Examples:
   pwgen generate --format appkey --segments 5 --segment-length 6
   pwgen generate --format guid
 `,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// Reuse existing flag variables defined in root.go
-		opts := generator.Options{
-			Format:        generator.Format(flagFormat),
-			Length:        flagLength,
-			UseLower:      flagUseLower,
-			UseUpper:      flagUseUpper,
-			UseNumber:     flagUseNumber,
-			UseSymbol:     flagUseSymbol,
-			MinLower:      flagMinLower,
-			MinUpper:      flagMinUpper,
-			MinNumber:     flagMinNumber,
-			MinSymbol:     flagMinSymbol,
-			Segments:      flagSegments,
-			SegmentLength: flagSegmentLength,
-		}
-
-		out, err := generator.Generate(opts)
-		if err != nil {
-			return err
-		}
-		cmd.Println(out)
-		return nil
-	},
+	// Reuses rootCmd's runGenerate so there is a single call site into
+	// generator.Generate for the CLI.
+	PreRunE: validateFormatFlags,
+	RunE:    runGenerate,
 }
 
 func init() {
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/jokruger/pwgen/internal/generator"
@@ -21,6 +23,30 @@ var (
 	flagMinSymbol     int
 	flagSegments      int
 	flagSegmentLength int
+
+	flagWords        int
+	flagSeparator    string
+	flagCapitalize   bool
+	flagAppendNumber bool
+	flagWordlist     string
+	flagWordlistFile string
+
+	flagPattern string
+	flagCount   int
+
+	flagExcludeAmbiguous bool
+	flagExcludeSimilar   bool
+	flagExclude          string
+	flagCharsetLower     string
+	flagCharsetUpper     string
+	flagCharsetNumber    string
+	flagCharsetSymbol    string
+
+	flagShowEntropy bool
+	flagMinEntropy  float64
+
+	flagOutput     string
+	flagOutputFile string
 )
 
 var rootCmd = &cobra.Command{
@@ -30,16 +56,19 @@ var rootCmd = &cobra.Command{
 
 Formats:
   generic (default) - random characters according to selected classes
-  appkey            - segmented key (e.g. XXXX-XXXX-XXXX)
-  guid              - RFC 4122 UUID v4
+  appkey             - segmented key (e.g. XXXX-XXXX-XXXX)
+  guid               - RFC 4122 UUID v4
+  passphrase         - diceware-style words (e.g. correct-horse-battery-staple-9)
+  pattern            - template-driven tokens (e.g. AAAA-9999-aaaa)
 
 Character classes can be toggled and minimum counts enforced.`,
-	RunE: runGenerate,
+	RunE:    runGenerate,
+	PreRunE: validateFormatFlags,
 }
 
 func init() {
 	rootCmd.PersistentFlags().IntVarP(&flagLength, "length", "l", 16, "Total password length (generic format)")
-	rootCmd.PersistentFlags().StringVarP(&flagFormat, "format", "f", "generic", "Output format: generic|appkey|guid")
+	rootCmd.PersistentFlags().StringVarP(&flagFormat, "format", "f", "generic", "Output format: generic|appkey|guid|passphrase|pattern")
 	rootCmd.PersistentFlags().BoolVar(&flagUseLower, "lower", true, "Include lowercase letters")
 	rootCmd.PersistentFlags().BoolVar(&flagUseUpper, "upper", true, "Include uppercase letters")
 	rootCmd.PersistentFlags().BoolVar(&flagUseNumber, "number", true, "Include numbers")
@@ -52,6 +81,50 @@ func init() {
 
 	rootCmd.PersistentFlags().IntVar(&flagSegments, "segments", 4, "Number of segments (appkey format)")
 	rootCmd.PersistentFlags().IntVar(&flagSegmentLength, "segment-length", 4, "Length of each segment (appkey format)")
+
+	rootCmd.PersistentFlags().IntVar(&flagWords, "words", 6, "Number of words (passphrase format)")
+	rootCmd.PersistentFlags().StringVar(&flagSeparator, "separator", "-", "Separator between words (passphrase format)")
+	rootCmd.PersistentFlags().BoolVar(&flagCapitalize, "capitalize", false, "Capitalize the first letter of each word (passphrase format)")
+	rootCmd.PersistentFlags().BoolVar(&flagAppendNumber, "append-number", false, "Append a random digit (passphrase format)")
+	rootCmd.PersistentFlags().StringVar(&flagWordlist, "wordlist", "eff-large", "Built-in wordlist: eff-large|eff-short1|diceware (passphrase format)")
+	rootCmd.PersistentFlags().StringVar(&flagWordlistFile, "wordlist-file", "", "Path to a custom newline-delimited wordlist, overrides --wordlist (passphrase format)")
+
+	rootCmd.PersistentFlags().StringVar(&flagPattern, "pattern", "", `Template, e.g. "AAAA-9999-aaaa-??" (pattern format)`)
+	rootCmd.PersistentFlags().IntVar(&flagCount, "count", 1, "Number of values to generate (distinct, except json/csv output above 10000 without --min-entropy)")
+
+	rootCmd.PersistentFlags().BoolVar(&flagExcludeAmbiguous, "exclude-ambiguous", false, "Exclude ambiguous punctuation, e.g. {}[]()|")
+	rootCmd.PersistentFlags().BoolVar(&flagExcludeSimilar, "exclude-similar", false, "Exclude visually-similar letters/digits, e.g. O0oIl1")
+	rootCmd.PersistentFlags().StringVar(&flagExclude, "exclude", "", "Arbitrary additional characters to exclude")
+	rootCmd.PersistentFlags().StringVar(&flagCharsetLower, "charset-lower", "", "Custom lowercase charset, overrides the built-in set")
+	rootCmd.PersistentFlags().StringVar(&flagCharsetUpper, "charset-upper", "", "Custom uppercase charset, overrides the built-in set")
+	rootCmd.PersistentFlags().StringVar(&flagCharsetNumber, "charset-number", "", "Custom number charset, overrides the built-in set")
+	rootCmd.PersistentFlags().StringVar(&flagCharsetSymbol, "charset-symbol", "", "Custom symbol charset, overrides the built-in set")
+
+	rootCmd.PersistentFlags().BoolVar(&flagShowEntropy, "show-entropy", false, "Print an entropy/strength estimate alongside each generated value")
+	rootCmd.PersistentFlags().Float64Var(&flagMinEntropy, "min-entropy", 0, "Regenerate (bounded) until estimated entropy is at least this many bits")
+
+	rootCmd.PersistentFlags().StringVar(&flagOutput, "output", "plain", "Output format: plain|json|csv")
+	rootCmd.PersistentFlags().StringVar(&flagOutputFile, "output-file", "", "Write output to this file instead of stdout")
+}
+
+// formatIncompatibleFlags lists, per format, the CLI flags that don't apply
+// to it. Used to reject conflicting combinations early, e.g. --length with
+// passphrase.
+var formatIncompatibleFlags = map[generator.Format][]string{
+	generator.FormatPassphrase: {"length", "segments", "segment-length"},
+	generator.FormatPattern:    {"length", "segments", "segment-length"},
+}
+
+// validateFormatFlags rejects flags that were explicitly set but don't apply
+// to the selected format.
+func validateFormatFlags(cmd *cobra.Command, args []string) error {
+	format := generator.Format(flagFormat)
+	for _, name := range formatIncompatibleFlags[format] {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s is not applicable to format %q", name, format)
+		}
+	}
+	return nil
 }
 
 func Execute() {
@@ -61,8 +134,11 @@ func Execute() {
 	}
 }
 
-func runGenerate(cmd *cobra.Command, args []string) error {
-	opts := generator.Options{
+// optionsFromFlags builds generator.Options from the persistent CLI flags.
+// It is the single place where flag state is translated into Options, so
+// every command (root, generate) stays in sync.
+func optionsFromFlags() generator.Options {
+	return generator.Options{
 		Format:        generator.Format(flagFormat),
 		Length:        flagLength,
 		UseLower:      flagUseLower,
@@ -75,12 +151,151 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		MinSymbol:     flagMinSymbol,
 		Segments:      flagSegments,
 		SegmentLength: flagSegmentLength,
+		WordCount:     flagWords,
+		WordSeparator: flagSeparator,
+		Capitalize:    flagCapitalize,
+		AppendNumber:  flagAppendNumber,
+		Wordlist:      flagWordlist,
+		WordlistFile:  flagWordlistFile,
+		Pattern:       flagPattern,
+
+		ExcludeAmbiguous: flagExcludeAmbiguous,
+		ExcludeSimilar:   flagExcludeSimilar,
+		Exclude:          flagExclude,
+		CustomLower:      flagCharsetLower,
+		CustomUpper:      flagCharsetUpper,
+		CustomNumber:     flagCharsetNumber,
+		CustomSymbol:     flagCharsetSymbol,
 	}
+}
 
-	pw, err := generator.Generate(opts)
+// runGenerate is the single call site invoking generator.Generate for the
+// CLI; both rootCmd and generateCmd share it. --count emits that many
+// values, via --output plain|json|csv to stdout or --output-file. Values are
+// distinct, except for json/csv output without --min-entropy above
+// generator.Stream's deduplication bound (10,000 values).
+func runGenerate(cmd *cobra.Command, args []string) error {
+	opts := optionsFromFlags()
+
+	count := flagCount
+	if count <= 0 {
+		count = 1
+	}
+
+	w, closeOutput, err := openOutput()
 	if err != nil {
 		return err
 	}
-	fmt.Println(pw)
+	defer closeOutput()
+
+	if flagOutput == "" || flagOutput == "plain" {
+		return runGeneratePlain(w, opts, count)
+	}
+	return runGenerateStructured(w, opts, count)
+}
+
+// runGeneratePlain preserves the original line-per-password stdout
+// behavior, with an optional entropy line under each one.
+func runGeneratePlain(w io.Writer, opts generator.Options, count int) error {
+	seen := make(map[string]bool, count)
+	for i := 0; i < count; i++ {
+		pw, report, err := generateMeetingMinEntropy(opts, seen)
+		if err != nil {
+			return err
+		}
+		seen[pw] = true
+		fmt.Fprintln(w, pw)
+		if flagShowEntropy {
+			fmt.Fprintf(w, "  entropy: %.1f bits (%s)\n", report.EntropyBits, report.StrengthLabel)
+		}
+	}
 	return nil
 }
+
+// runGenerateStructured handles --output json|csv. When --min-entropy isn't
+// set it streams through generator.Stream; otherwise each value must be
+// regenerated against the threshold, so it encodes manually.
+func runGenerateStructured(w io.Writer, opts generator.Options, count int) error {
+	enc, err := newEncoder(flagOutput)
+	if err != nil {
+		return err
+	}
+
+	if flagMinEntropy <= 0 {
+		return generator.Stream(context.Background(), opts, count, w, enc)
+	}
+
+	if err := enc.Begin(w); err != nil {
+		return err
+	}
+	seen := make(map[string]bool, count)
+	for i := 0; i < count; i++ {
+		pw, report, err := generateMeetingMinEntropy(opts, seen)
+		if err != nil {
+			return err
+		}
+		seen[pw] = true
+		result := generator.Result{
+			Value:       pw,
+			Format:      string(opts.Format),
+			Length:      len([]rune(pw)),
+			EntropyBits: report.EntropyBits,
+		}
+		if err := enc.Encode(w, result); err != nil {
+			return err
+		}
+	}
+	return enc.End(w)
+}
+
+func newEncoder(output string) (generator.Encoder, error) {
+	switch output {
+	case "json":
+		return generator.NewJSONEncoder(), nil
+	case "csv":
+		return generator.NewCSVEncoder(), nil
+	default:
+		return nil, fmt.Errorf("unknown --output %q (want plain|json|csv)", output)
+	}
+}
+
+// openOutput returns the destination writer for generated output: stdout,
+// or --output-file if set.
+func openOutput() (io.Writer, func() error, error) {
+	if flagOutputFile == "" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(flagOutputFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening --output-file: %w", err)
+	}
+	return f, f.Close, nil
+}
+
+// maxMinEntropyAttempts bounds the --min-entropy regeneration loop.
+const maxMinEntropyAttempts = 1000
+
+// generateMeetingMinEntropy generates a value via generator.Generate, not
+// already present in seen, regenerating (bounded) until it is distinct and
+// meets --min-entropy, if set.
+func generateMeetingMinEntropy(o generator.Options, seen map[string]bool) (string, generator.Report, error) {
+	for attempt := 1; ; attempt++ {
+		pw, err := generator.Generate(o)
+		if err != nil {
+			return "", generator.Report{}, err
+		}
+		if seen[pw] {
+			if attempt >= maxMinEntropyAttempts {
+				return "", generator.Report{}, fmt.Errorf("could not generate a distinct value within %d attempts (generation space likely exhausted)", maxMinEntropyAttempts)
+			}
+			continue
+		}
+		report := generator.Analyze(pw, o)
+		if flagMinEntropy <= 0 || report.EntropyBits >= flagMinEntropy {
+			return pw, report, nil
+		}
+		if attempt >= maxMinEntropyAttempts {
+			return "", generator.Report{}, fmt.Errorf("could not reach --min-entropy %.1f within %d attempts (best: %.1f bits)", flagMinEntropy, maxMinEntropyAttempts, report.EntropyBits)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jokruger/pwgen/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagServeAddr      string
+	flagServeRateLimit int
+)
+
+// serveCmd runs pwgen as an HTTP service, so the generator can be consumed
+// as a container-native microservice rather than invoked per-process.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run pwgen as an HTTP service",
+	Long: `Starts an HTTP server exposing the generator over JSON.
+
+Endpoints:
+  GET  /healthz                          - liveness check
+  GET  /generate?format=generic&length=32 - generate via query params
+  POST /generate                          - generate via a JSON generator.Options body
+
+Append "?bulk=N" to /generate to return an array of N results in one call.`,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&flagServeAddr, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().IntVar(&flagServeRateLimit, "rate-limit", 0, "Max requests per second across all clients (0 disables limiting)")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	srv := server.New(server.Options{
+		Addr:      flagServeAddr,
+		RateLimit: flagServeRateLimit,
+	})
+	fmt.Fprintf(cmd.OutOrStdout(), "pwgen serving on %s\n", flagServeAddr)
+	return srv.ListenAndServe()
+}
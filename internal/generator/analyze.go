@@ -0,0 +1,246 @@
+package generator
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//go:embed wordlists/common_passwords.txt
+var commonPasswordsData string
+
+var commonPasswords = buildCommonPasswordSet()
+
+func buildCommonPasswordSet() map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range splitWordlist(commonPasswordsData) {
+		set[strings.ToLower(w)] = true
+	}
+	return set
+}
+
+// sequentialRuns are short, highly guessable substrings (keyboard rows,
+// ascending digits) that downgrade a strength estimate even when raw
+// entropy looks fine.
+var sequentialRuns = []string{
+	"0123456789", "123456789", "qwertyuiop", "asdfghjkl", "zxcvbnm", "qwerty",
+}
+
+// Assumed guesses/second for a throttled online attack and an offline
+// attack against a fast hash, used to estimate crack time.
+const (
+	onlineGuessesPerSecond  = 10.0
+	offlineGuessesPerSecond = 1e10
+)
+
+// maxCrackTime caps the reported duration so very high entropy estimates
+// don't overflow time.Duration.
+const maxCrackTime = 200 * 365 * 24 * time.Hour
+
+// Report summarizes the estimated strength of a generated or arbitrary
+// password/passphrase/key.
+type Report struct {
+	EntropyBits      float64
+	ClassesUsed      []string
+	CharsetSize      int
+	StrengthLabel    string
+	CrackTimeOnline  time.Duration
+	CrackTimeOffline time.Duration
+}
+
+// Analyze estimates the strength of pw. For o.Format of FormatGeneric,
+// FormatAppKey, or FormatPassphrase, entropy is computed from the
+// configuration that produced pw; otherwise pw is walked to detect the
+// character classes actually present, so arbitrary user-supplied strings
+// can be analyzed too. A lightweight dictionary/pattern check downgrades
+// the estimate for common passwords and keyboard-walk or repeated-run
+// substrings, without pulling in a full zxcvbn port.
+func Analyze(pw string, o Options) Report {
+	bits, classes, charsetSize := estimateEntropy(pw, o)
+	bits = applyGuessabilityPenalty(pw, bits)
+
+	return Report{
+		EntropyBits:      bits,
+		ClassesUsed:      classes,
+		CharsetSize:      charsetSize,
+		StrengthLabel:    strengthLabel(bits),
+		CrackTimeOnline:  crackTime(bits, onlineGuessesPerSecond),
+		CrackTimeOffline: crackTime(bits, offlineGuessesPerSecond),
+	}
+}
+
+// estimateEntropy computes raw Shannon-style entropy before any
+// guessability penalty is applied.
+func estimateEntropy(pw string, o Options) (bits float64, classes []string, charsetSize int) {
+	switch o.Format {
+	case FormatPassphrase:
+		if words, err := loadWordlist(o); err == nil && len(words) > 0 {
+			n := o.WordCount
+			if n <= 0 {
+				n = len(strings.Split(pw, o.WordSeparator))
+			}
+			bits := float64(n)*math.Log2(float64(len(words))) + passphraseBonusBits(o)
+			return bits, []string{"words"}, len(words)
+		}
+	case FormatGeneric, FormatAppKey:
+		if sets, _, err := collectClassesAndValidate(o); err == nil {
+			all := concatClasses(sets)
+			return float64(len([]rune(pw))) * math.Log2(float64(len(all))), classesFromOptions(o), len(all)
+		}
+	}
+	return detectEntropy(pw)
+}
+
+// passphraseBonusBits accounts for randomness a passphrase draws on top of
+// its word choices. AppendNumber appends a digit chosen uniformly via
+// randomIndex(10), a genuine extra ~3.3 bits. Capitalize is applied to every
+// word unconditionally (see capitalizeWord), so it's not a secret the
+// generator chose and contributes no bonus bits.
+func passphraseBonusBits(o Options) float64 {
+	bits := 0.0
+	if o.AppendNumber {
+		bits += math.Log2(10)
+	}
+	return bits
+}
+
+// classesFromOptions lists the character classes enabled on o.
+func classesFromOptions(o Options) []string {
+	var classes []string
+	if o.UseLower {
+		classes = append(classes, "lower")
+	}
+	if o.UseUpper {
+		classes = append(classes, "upper")
+	}
+	if o.UseNumber {
+		classes = append(classes, "number")
+	}
+	if o.UseSymbol {
+		classes = append(classes, "symbol")
+	}
+	return classes
+}
+
+// detectEntropy estimates entropy for an arbitrary string by detecting
+// which character classes it draws from.
+func detectEntropy(pw string) (float64, []string, int) {
+	var hasLower, hasUpper, hasNumber, hasSymbol bool
+	for _, r := range pw {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasNumber = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	var classes []string
+	charsetSize := 0
+	if hasLower {
+		classes = append(classes, "lower")
+		charsetSize += len(lowerChars)
+	}
+	if hasUpper {
+		classes = append(classes, "upper")
+		charsetSize += len(upperChars)
+	}
+	if hasNumber {
+		classes = append(classes, "number")
+		charsetSize += len(numberChars)
+	}
+	if hasSymbol {
+		classes = append(classes, "symbol")
+		charsetSize += len(symbolChars)
+	}
+	if charsetSize == 0 {
+		charsetSize = 1
+	}
+
+	return float64(len([]rune(pw))) * math.Log2(float64(charsetSize)), classes, charsetSize
+}
+
+// applyGuessabilityPenalty downgrades bits for passwords that are in the
+// common-password dictionary or contain keyboard-walk / repeated-run
+// substrings, which make them far easier to guess than raw entropy implies.
+func applyGuessabilityPenalty(pw string, bits float64) float64 {
+	lower := strings.ToLower(pw)
+
+	if commonPasswords[lower] {
+		return math.Min(bits, 10)
+	}
+
+	for _, run := range sequentialRuns {
+		if strings.Contains(lower, run) || strings.Contains(lower, reverseString(run)) {
+			bits -= 20
+		}
+	}
+
+	if run := longestRepeatedRun(pw); run >= 3 {
+		bits -= float64(run) * 2
+	}
+
+	if bits < 0 {
+		bits = 0
+	}
+	return bits
+}
+
+// longestRepeatedRun returns the length of the longest run of the same
+// rune repeated consecutively, e.g. 4 for "aaaa".
+func longestRepeatedRun(pw string) int {
+	runes := []rune(pw)
+	best, cur := 0, 0
+	for i := range runes {
+		if i > 0 && runes[i] == runes[i-1] {
+			cur++
+		} else {
+			cur = 1
+		}
+		if cur > best {
+			best = cur
+		}
+	}
+	return best
+}
+
+func reverseString(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// strengthLabel buckets an entropy estimate into a human-readable label.
+func strengthLabel(bits float64) string {
+	switch {
+	case bits < 28:
+		return "very weak"
+	case bits < 36:
+		return "weak"
+	case bits < 60:
+		return "fair"
+	case bits < 80:
+		return "strong"
+	default:
+		return "very strong"
+	}
+}
+
+// crackTime estimates the average-case time to guess a password of the
+// given entropy at guessesPerSecond, capped at maxCrackTime.
+func crackTime(bits float64, guessesPerSecond float64) time.Duration {
+	guesses := math.Pow(2, bits) / 2
+	seconds := guesses / guessesPerSecond
+	if math.IsInf(seconds, 1) || seconds > maxCrackTime.Seconds() {
+		return maxCrackTime
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -12,9 +13,11 @@ import (
 type Format string
 
 const (
-	FormatGeneric Format = "generic" // Random characters per enabled classes
-	FormatAppKey  Format = "appkey"  // Segmented groups (e.g. XXXX-XXXX-XXXX)
-	FormatGUID    Format = "guid"    // UUID v4
+	FormatGeneric    Format = "generic"    // Random characters per enabled classes
+	FormatAppKey     Format = "appkey"     // Segmented groups (e.g. XXXX-XXXX-XXXX)
+	FormatGUID       Format = "guid"       // UUID v4
+	FormatPassphrase Format = "passphrase" // Diceware-style words (e.g. correct-horse-battery-staple)
+	FormatPattern    Format = "pattern"    // Template-driven tokens (e.g. AAAA-9999-aaaa)
 )
 
 // Character class rune slices.
@@ -23,6 +26,12 @@ var (
 	upperChars  = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
 	numberChars = []rune("0123456789")
 	symbolChars = []rune("!@#$%^&*()-_=+[]{};:,.?/<>~")
+
+	// ambiguousChars are punctuation marks that are easily confused with
+	// each other in some fonts.
+	ambiguousChars = []rune("{}[]()|")
+	// similarChars are letters/digits that look alike in many fonts.
+	similarChars = []rune("O0oIl1")
 )
 
 // Options holds generation parameters.
@@ -43,13 +52,46 @@ type Options struct {
 	MinNumber int
 	MinSymbol int
 
+	// Readability filters, applied once when classes are collected
+	ExcludeAmbiguous bool   // Strip ambiguous punctuation, e.g. {}[]()|
+	ExcludeSimilar   bool   // Strip visually-similar letters/digits, e.g. O0oIl1
+	Exclude          string // Arbitrary additional runes to strip
+
+	// Custom character sets, overriding the built-in class (still subject
+	// to the exclusions above)
+	CustomLower  string
+	CustomUpper  string
+	CustomNumber string
+	CustomSymbol string
+
 	// App key specific
 	Segments      int // Number of segments (e.g. 4 -> XXXX-XXXX-XXXX-XXXX)
 	SegmentLength int // Characters per segment
+
+	// Passphrase specific
+	WordCount     int    // Number of words
+	WordSeparator string // Joined between words (and before an appended number)
+	Capitalize    bool   // Capitalize the first letter of each word
+	AppendNumber  bool   // Append a random digit at the end
+	Wordlist      string // Built-in wordlist name: eff-large, eff-short1, diceware
+	WordlistFile  string // Path to a custom newline-delimited wordlist; overrides Wordlist
+
+	// Pattern specific
+	Pattern string // Mini-language template, e.g. "AAAA-9999-aaaa-??"
 }
 
 // Generate produces a password / key string according to the provided Options.
 func Generate(o Options) (string, error) {
+	return GenerateContext(context.Background(), o)
+}
+
+// GenerateContext is Generate with cancellation support, so a long bulk run
+// (see GenerateN/Stream) can be interrupted.
+func GenerateContext(ctx context.Context, o Options) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	switch o.Format {
 	case FormatGUID:
 		return generateUUIDv4()
@@ -57,6 +99,10 @@ func Generate(o Options) (string, error) {
 		return generateAppKey(o)
 	case FormatGeneric:
 		return generateGeneric(o)
+	case FormatPassphrase:
+		return generatePassphrase(o)
+	case FormatPattern:
+		return generatePattern(o)
 	default:
 		return "", fmt.Errorf("unknown format: %s", o.Format)
 	}
@@ -143,34 +189,52 @@ func generateAppKey(o Options) (string, error) {
 	return b.String(), nil
 }
 
-// collectClassesAndValidate builds the enabled character sets and validates minima.
+// collectClassesAndValidate builds the enabled character sets and validates
+// minima. Custom overrides and exclusion filters (ambiguous/similar/explicit)
+// are applied here, once, so every caller sees the same effective sets.
 func collectClassesAndValidate(o Options) ([][]rune, []int, error) {
 	var sets [][]rune
 	var mins []int
 
 	if o.UseLower {
-		sets = append(sets, lowerChars)
+		set, err := effectiveClass(lowerChars, o.CustomLower, o)
+		if err != nil {
+			return nil, nil, fmt.Errorf("lowercase %w", err)
+		}
+		sets = append(sets, set)
 		mins = append(mins, o.MinLower)
 	} else if o.MinLower > 0 {
 		return nil, nil, errors.New("min-lower specified but lowercase disabled")
 	}
 
 	if o.UseUpper {
-		sets = append(sets, upperChars)
+		set, err := effectiveClass(upperChars, o.CustomUpper, o)
+		if err != nil {
+			return nil, nil, fmt.Errorf("uppercase %w", err)
+		}
+		sets = append(sets, set)
 		mins = append(mins, o.MinUpper)
 	} else if o.MinUpper > 0 {
 		return nil, nil, errors.New("min-upper specified but uppercase disabled")
 	}
 
 	if o.UseNumber {
-		sets = append(sets, numberChars)
+		set, err := effectiveClass(numberChars, o.CustomNumber, o)
+		if err != nil {
+			return nil, nil, fmt.Errorf("number %w", err)
+		}
+		sets = append(sets, set)
 		mins = append(mins, o.MinNumber)
 	} else if o.MinNumber > 0 {
 		return nil, nil, errors.New("min-number specified but numbers disabled")
 	}
 
 	if o.UseSymbol {
-		sets = append(sets, symbolChars)
+		set, err := effectiveClass(symbolChars, o.CustomSymbol, o)
+		if err != nil {
+			return nil, nil, fmt.Errorf("symbol %w", err)
+		}
+		sets = append(sets, set)
 		mins = append(mins, o.MinSymbol)
 	} else if o.MinSymbol > 0 {
 		return nil, nil, errors.New("min-symbol specified but symbols disabled")
@@ -189,6 +253,52 @@ func collectClassesAndValidate(o Options) ([][]rune, []int, error) {
 	return sets, mins, nil
 }
 
+// effectiveClass resolves the rune set for one class: a custom override
+// replaces the built-in set, then ambiguous/similar/explicit exclusions are
+// applied. Returns an error if the set ends up empty.
+func effectiveClass(base []rune, custom string, o Options) ([]rune, error) {
+	set := base
+	if custom != "" {
+		set = []rune(custom)
+	}
+	set = excludeRunes(set, o)
+	if len(set) == 0 {
+		return nil, errors.New("charset is empty after exclusions")
+	}
+	return set, nil
+}
+
+// excludeRunes strips ambiguous, visually-similar, and explicitly excluded
+// runes from set.
+func excludeRunes(set []rune, o Options) []rune {
+	if !o.ExcludeAmbiguous && !o.ExcludeSimilar && o.Exclude == "" {
+		return set
+	}
+
+	excluded := make(map[rune]bool)
+	if o.ExcludeAmbiguous {
+		for _, r := range ambiguousChars {
+			excluded[r] = true
+		}
+	}
+	if o.ExcludeSimilar {
+		for _, r := range similarChars {
+			excluded[r] = true
+		}
+	}
+	for _, r := range o.Exclude {
+		excluded[r] = true
+	}
+
+	out := make([]rune, 0, len(set))
+	for _, r := range set {
+		if !excluded[r] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
 // concatClasses merges rune slices into a single slice.
 func concatClasses(classes [][]rune) []rune {
 	total := 0
@@ -207,11 +317,24 @@ func randomRune(set []rune) (rune, error) {
 	if len(set) == 0 {
 		return 0, errors.New("empty character set")
 	}
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	idx, err := randomIndex(len(set))
+	if err != nil {
+		return 0, err
+	}
+	return set[idx], nil
+}
+
+// randomIndex returns a cryptographically random index in [0, n) with
+// uniform probability.
+func randomIndex(n int) (int, error) {
+	if n <= 0 {
+		return 0, errors.New("n must be > 0")
+	}
+	idx, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
 	if err != nil {
 		return 0, err
 	}
-	return set[n.Int64()], nil
+	return int(idx.Int64()), nil
 }
 
 // shuffleRunes performs an in-place Fisher–Yates shuffle using crypto/rand.
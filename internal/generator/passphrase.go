@@ -0,0 +1,120 @@
+package generator
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// The embedded wordlists are real English dictionary words, not the
+// verbatim published EFF/diceware corpora -- the names below describe the
+// built-in option, not a byte-for-byte copy, and their word counts differ
+// from the published lists. Each entry is still a genuine, pronounceable
+// word suitable for memorable passphrases.
+//
+//go:embed wordlists/eff_large.txt
+var wordlistEFFLarge string
+
+//go:embed wordlists/eff_short1.txt
+var wordlistEFFShort1 string
+
+//go:embed wordlists/diceware.txt
+var wordlistDiceware string
+
+// builtinWordlists maps the --wordlist CLI values to their embedded source.
+var builtinWordlists = map[string]string{
+	"eff-large":  wordlistEFFLarge,
+	"eff-short1": wordlistEFFShort1,
+	"diceware":   wordlistDiceware,
+}
+
+// generatePassphrase builds a human-memorable passphrase of whole words,
+// e.g. "correct-horse-battery-staple-9", as an alternative to the
+// random-character formats.
+func generatePassphrase(o Options) (string, error) {
+	if o.WordCount <= 0 {
+		return "", errors.New("word count must be > 0")
+	}
+
+	words, err := loadWordlist(o)
+	if err != nil {
+		return "", err
+	}
+	if len(words) == 0 {
+		return "", errors.New("wordlist is empty")
+	}
+
+	picked := make([]string, o.WordCount)
+	for i := range picked {
+		idx, err := randomIndex(len(words))
+		if err != nil {
+			return "", err
+		}
+		word := words[idx]
+		if o.Capitalize {
+			word = capitalizeWord(word)
+		}
+		picked[i] = word
+	}
+
+	out := strings.Join(picked, o.WordSeparator)
+	if o.AppendNumber {
+		n, err := randomIndex(10)
+		if err != nil {
+			return "", err
+		}
+		out += fmt.Sprintf("%s%d", o.WordSeparator, n)
+	}
+
+	return out, nil
+}
+
+// loadWordlist resolves the word source for Options: a custom file takes
+// precedence over a named built-in list, which defaults to "eff-large".
+func loadWordlist(o Options) ([]string, error) {
+	if o.WordlistFile != "" {
+		data, err := os.ReadFile(o.WordlistFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading wordlist file: %w", err)
+		}
+		return splitWordlist(string(data)), nil
+	}
+
+	name := o.Wordlist
+	if name == "" {
+		name = "eff-large"
+	}
+	data, ok := builtinWordlists[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown wordlist %q", name)
+	}
+	return splitWordlist(data), nil
+}
+
+// splitWordlist turns a newline-delimited wordlist into a clean slice,
+// skipping blank lines.
+func splitWordlist(data string) []string {
+	lines := strings.Split(data, "\n")
+	out := make([]string, 0, len(lines))
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		out = append(out, l)
+	}
+	return out
+}
+
+// capitalizeWord upper-cases the first rune of a word, leaving the rest untouched.
+func capitalizeWord(w string) string {
+	if w == "" {
+		return w
+	}
+	r := []rune(w)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
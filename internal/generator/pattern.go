@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Hex digit rune slices, used by the 'x'/'X' pattern meta-characters.
+var (
+	hexLowerChars = []rune("0123456789abcdef")
+	hexUpperChars = []rune("0123456789ABCDEF")
+)
+
+// maxPatternAttempts bounds the post-check retry loop used to satisfy
+// Min* constraints on pattern output.
+const maxPatternAttempts = 1000
+
+// PatternSyntaxError reports a malformed Options.Pattern, pointing at the
+// 1-indexed column of the offending character.
+type PatternSyntaxError struct {
+	Pattern string
+	Column  int
+	Msg     string
+}
+
+func (e *PatternSyntaxError) Error() string {
+	return fmt.Sprintf("invalid pattern %q at column %d: %s", e.Pattern, e.Column, e.Msg)
+}
+
+// patternPiece is one resolved position in a pattern: either a fixed literal
+// rune, or a character set to draw a random rune from.
+type patternPiece struct {
+	literal   rune
+	isLiteral bool
+	set       []rune
+}
+
+// generatePattern builds a string matching Options.Pattern, a small
+// mini-language for structured tokens such as license keys or coupon codes:
+//
+//	A upper   a lower   9 digit   s symbol   x hex-lower   X hex-upper
+//	* any enabled class   \ escapes the next literal character
+//
+// Any other character passes through as a literal. If Min* fields are set,
+// the result is regenerated (bounded) until they are satisfied.
+func generatePattern(o Options) (string, error) {
+	if o.Pattern == "" {
+		return "", errors.New("pattern must not be empty")
+	}
+
+	pieces, err := parsePattern(o.Pattern, o)
+	if err != nil {
+		return "", err
+	}
+
+	for attempt := 0; attempt < maxPatternAttempts; attempt++ {
+		out, err := renderPattern(pieces)
+		if err != nil {
+			return "", err
+		}
+		if satisfiesPatternMinima(out, o) {
+			return out, nil
+		}
+	}
+	return "", fmt.Errorf("could not satisfy minimum class counts within %d attempts", maxPatternAttempts)
+}
+
+// parsePattern compiles a pattern string into a sequence of pieces.
+func parsePattern(pattern string, o Options) ([]patternPiece, error) {
+	runes := []rune(pattern)
+	pieces := make([]patternPiece, 0, len(runes))
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		if c == '\\' {
+			if i+1 >= len(runes) {
+				return nil, &PatternSyntaxError{Pattern: pattern, Column: i + 1, Msg: "dangling escape at end of pattern"}
+			}
+			i++
+			pieces = append(pieces, patternPiece{literal: runes[i], isLiteral: true})
+			continue
+		}
+
+		switch c {
+		case 'A':
+			pieces = append(pieces, patternPiece{set: upperChars})
+		case 'a':
+			pieces = append(pieces, patternPiece{set: lowerChars})
+		case '9':
+			pieces = append(pieces, patternPiece{set: numberChars})
+		case 's':
+			pieces = append(pieces, patternPiece{set: symbolChars})
+		case 'x':
+			pieces = append(pieces, patternPiece{set: hexLowerChars})
+		case 'X':
+			pieces = append(pieces, patternPiece{set: hexUpperChars})
+		case '*':
+			set, err := anyEnabledClass(o)
+			if err != nil {
+				return nil, &PatternSyntaxError{Pattern: pattern, Column: i + 1, Msg: err.Error()}
+			}
+			pieces = append(pieces, patternPiece{set: set})
+		default:
+			pieces = append(pieces, patternPiece{literal: c, isLiteral: true})
+		}
+	}
+
+	return pieces, nil
+}
+
+// anyEnabledClass returns the union of character classes enabled on o, for
+// use by the '*' pattern meta-character.
+func anyEnabledClass(o Options) ([]rune, error) {
+	sets, _, err := collectClassesAndValidate(o)
+	if err != nil {
+		return nil, err
+	}
+	return concatClasses(sets), nil
+}
+
+// renderPattern draws one candidate string from a compiled pattern.
+func renderPattern(pieces []patternPiece) (string, error) {
+	out := make([]rune, len(pieces))
+	for i, p := range pieces {
+		if p.isLiteral {
+			out[i] = p.literal
+			continue
+		}
+		r, err := randomRune(p.set)
+		if err != nil {
+			return "", err
+		}
+		out[i] = r
+	}
+	return string(out), nil
+}
+
+// satisfiesPatternMinima reports whether s contains at least the Min*
+// counts of each class configured on o. A zero Min* imposes no requirement.
+func satisfiesPatternMinima(s string, o Options) bool {
+	var lower, upper, number, symbol int
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsDigit(r):
+			number++
+		case strings.ContainsRune(string(symbolChars), r):
+			symbol++
+		}
+	}
+	return lower >= o.MinLower && upper >= o.MinUpper && number >= o.MinNumber && symbol >= o.MinSymbol
+}
@@ -0,0 +1,194 @@
+package generator
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Result is one generated value plus the metadata reported in bulk output.
+type Result struct {
+	Value       string  `json:"value"`
+	Format      string  `json:"format"`
+	Length      int     `json:"length"`
+	EntropyBits float64 `json:"entropy_bits"`
+}
+
+// maxDedupAttempts bounds how many times generateDistinct will regenerate a
+// colliding value before giving up, so a small generation space (e.g.
+// --format pattern --pattern '9') fails loudly instead of looping forever.
+const maxDedupAttempts = 1000
+
+// generateDistinct generates a value not already present in seen, retrying
+// (bounded by maxDedupAttempts) on collision.
+func generateDistinct(ctx context.Context, o Options, seen map[string]bool) (string, error) {
+	for attempt := 0; attempt < maxDedupAttempts; attempt++ {
+		pw, err := GenerateContext(ctx, o)
+		if err != nil {
+			return "", err
+		}
+		if !seen[pw] {
+			return pw, nil
+		}
+	}
+	return "", fmt.Errorf("could not generate a distinct value after %d attempts (generation space likely exhausted)", maxDedupAttempts)
+}
+
+// GenerateN generates n distinct values according to o, buffering them in
+// memory. For very large n, prefer Stream so results aren't all held at once.
+// It returns an error if the generation space is exhausted before n distinct
+// values are found.
+func GenerateN(o Options, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be > 0")
+	}
+
+	ctx := context.Background()
+	seen := make(map[string]bool, n)
+	out := make([]string, 0, n)
+	for len(out) < n {
+		pw, err := generateDistinct(ctx, o, seen)
+		if err != nil {
+			return nil, err
+		}
+		seen[pw] = true
+		out = append(out, pw)
+	}
+	return out, nil
+}
+
+// Encoder serializes a stream of Results to a writer. Begin/End bracket the
+// stream (e.g. a JSON array or a CSV header row); Encode writes one Result.
+type Encoder interface {
+	Begin(w io.Writer) error
+	Encode(w io.Writer, r Result) error
+	End(w io.Writer) error
+}
+
+// maxStreamDedupN caps how large n can be for Stream to still deduplicate.
+// Deduplication needs a seen-values map that grows with n, which would
+// defeat Stream's whole point for a large bulk run (e.g. `--count 1000000`
+// load testing); past this bound Stream falls back to plain generation and
+// stays O(1) memory. Below it, results come out distinct, which matters for
+// small/bounded spaces like short `--format pattern` templates.
+const maxStreamDedupN = 10_000
+
+// Stream generates n values and writes them to w incrementally via enc,
+// rather than buffering the full result set, so library consumers can plug
+// bulk generation into a pipeline. It stops early if ctx is canceled, e.g.
+// to interrupt a `--count 1000000` load-testing run. For n up to
+// maxStreamDedupN the values are distinct (erroring if the generation space
+// is exhausted first); above that bound, Stream skips deduplication so
+// memory use stays constant regardless of n.
+func Stream(ctx context.Context, o Options, n int, w io.Writer, enc Encoder) error {
+	if n <= 0 {
+		return fmt.Errorf("n must be > 0")
+	}
+
+	if err := enc.Begin(w); err != nil {
+		return err
+	}
+
+	var seen map[string]bool
+	if n <= maxStreamDedupN {
+		seen = make(map[string]bool, n)
+	}
+
+	for i := 0; i < n; i++ {
+		var pw string
+		var err error
+		if seen != nil {
+			pw, err = generateDistinct(ctx, o, seen)
+			if err == nil {
+				seen[pw] = true
+			}
+		} else {
+			pw, err = GenerateContext(ctx, o)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = enc.Encode(w, Result{
+			Value:       pw,
+			Format:      string(o.Format),
+			Length:      len([]rune(pw)),
+			EntropyBits: Analyze(pw, o).EntropyBits,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return enc.End(w)
+}
+
+// JSONEncoder writes results as a single JSON array of Result objects.
+type JSONEncoder struct {
+	wroteFirst bool
+}
+
+func NewJSONEncoder() *JSONEncoder { return &JSONEncoder{} }
+
+func (e *JSONEncoder) Begin(w io.Writer) error {
+	_, err := io.WriteString(w, "[")
+	return err
+}
+
+func (e *JSONEncoder) Encode(w io.Writer, r Result) error {
+	if e.wroteFirst {
+		if _, err := io.WriteString(w, ","); err != nil {
+			return err
+		}
+	}
+	e.wroteFirst = true
+	return json.NewEncoder(w).Encode(r)
+}
+
+func (e *JSONEncoder) End(w io.Writer) error {
+	_, err := io.WriteString(w, "]\n")
+	return err
+}
+
+// CSVEncoder writes results as CSV with a header row.
+type CSVEncoder struct {
+	w *csv.Writer
+}
+
+func NewCSVEncoder() *CSVEncoder { return &CSVEncoder{} }
+
+func (e *CSVEncoder) Begin(w io.Writer) error {
+	e.w = csv.NewWriter(w)
+	return e.w.Write([]string{"value", "format", "length", "entropy_bits"})
+}
+
+func (e *CSVEncoder) Encode(w io.Writer, r Result) error {
+	return e.w.Write([]string{
+		r.Value,
+		r.Format,
+		strconv.Itoa(r.Length),
+		strconv.FormatFloat(r.EntropyBits, 'f', 2, 64),
+	})
+}
+
+func (e *CSVEncoder) End(w io.Writer) error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// PlainEncoder writes one value per line, with no header or metadata.
+type PlainEncoder struct{}
+
+func NewPlainEncoder() *PlainEncoder { return &PlainEncoder{} }
+
+func (PlainEncoder) Begin(io.Writer) error { return nil }
+
+func (PlainEncoder) Encode(w io.Writer, r Result) error {
+	_, err := fmt.Fprintln(w, r.Value)
+	return err
+}
+
+func (PlainEncoder) End(io.Writer) error { return nil }
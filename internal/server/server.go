@@ -0,0 +1,235 @@
+// Package server exposes the password generator over HTTP, so pwgen can run
+// as a container-native microservice in addition to its CLI mode.
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jokruger/pwgen/internal/generator"
+)
+
+// Options configures the HTTP server.
+type Options struct {
+	Addr      string // e.g. ":8080"
+	RateLimit int    // max requests per second across all clients, 0 disables limiting
+}
+
+// generateResponse is the JSON shape returned by /generate.
+type generateResponse struct {
+	Password    string  `json:"password"`
+	Format      string  `json:"format"`
+	EntropyBits float64 `json:"entropy_bits"`
+}
+
+// New builds an *http.Server exposing the generator over JSON.
+func New(o Options) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", handleHealthz)
+	mux.HandleFunc("/generate", handleGenerate)
+
+	var h http.Handler = mux
+	if o.RateLimit > 0 {
+		h = rateLimit(o.RateLimit, h)
+	}
+	h = logRequests(h)
+
+	return &http.Server{
+		Addr:    o.Addr,
+		Handler: h,
+	}
+}
+
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("ok"))
+}
+
+// handleGenerate serves both GET (query-param driven) and POST (full
+// generator.Options JSON body) requests, optionally returning a bulk array
+// of results via "?bulk=N".
+func handleGenerate(w http.ResponseWriter, r *http.Request) {
+	var opts generator.Options
+	var err error
+
+	switch r.Method {
+	case http.MethodGet:
+		opts, err = optionsFromQuery(r.URL.Query())
+	case http.MethodPost:
+		err = json.NewDecoder(r.Body).Decode(&opts)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// WordlistFile lets the CLI load an arbitrary local file; honoring it
+	// here would let any caller read server-local files back out through
+	// generated passphrases. Serve mode only offers the built-in wordlists.
+	opts.WordlistFile = ""
+
+	bulkParam := r.URL.Query().Get("bulk")
+	bulk := 1
+	if bulkParam != "" {
+		n, convErr := strconv.Atoi(bulkParam)
+		if convErr != nil || n <= 0 {
+			http.Error(w, "bulk must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		bulk = n
+	}
+
+	results := make([]generateResponse, 0, bulk)
+	for i := 0; i < bulk; i++ {
+		pw, genErr := generator.Generate(opts)
+		if genErr != nil {
+			http.Error(w, genErr.Error(), http.StatusBadRequest)
+			return
+		}
+		results = append(results, generateResponse{
+			Password:    pw,
+			Format:      string(opts.Format),
+			EntropyBits: generator.Analyze(pw, opts).EntropyBits,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if bulkParam == "" {
+		json.NewEncoder(w).Encode(results[0])
+		return
+	}
+	json.NewEncoder(w).Encode(results)
+}
+
+// optionsFromQuery builds generator.Options from URL query parameters,
+// starting from generator.DefaultOptions() so unspecified fields behave the
+// same as the CLI's defaults.
+func optionsFromQuery(q url.Values) (generator.Options, error) {
+	o := generator.DefaultOptions()
+	o.Segments = 4
+	o.SegmentLength = 4
+	o.WordCount = 6
+	o.WordSeparator = "-"
+	o.Wordlist = "eff-large"
+
+	if v := q.Get("format"); v != "" {
+		o.Format = generator.Format(v)
+	}
+
+	intFields := []struct {
+		name string
+		dst  *int
+	}{
+		{"length", &o.Length},
+		{"min-lower", &o.MinLower},
+		{"min-upper", &o.MinUpper},
+		{"min-number", &o.MinNumber},
+		{"min-symbol", &o.MinSymbol},
+		{"segments", &o.Segments},
+		{"segment-length", &o.SegmentLength},
+		{"words", &o.WordCount},
+	}
+	for _, f := range intFields {
+		if v := q.Get(f.name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return o, err
+			}
+			*f.dst = n
+		}
+	}
+
+	boolFields := []struct {
+		name string
+		dst  *bool
+	}{
+		{"lower", &o.UseLower},
+		{"upper", &o.UseUpper},
+		{"number", &o.UseNumber},
+		{"symbol", &o.UseSymbol},
+		{"capitalize", &o.Capitalize},
+		{"append-number", &o.AppendNumber},
+	}
+	for _, f := range boolFields {
+		if v := q.Get(f.name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return o, err
+			}
+			*f.dst = b
+		}
+	}
+
+	stringFields := []struct {
+		name string
+		dst  *string
+	}{
+		{"separator", &o.WordSeparator},
+		{"wordlist", &o.Wordlist},
+		{"pattern", &o.Pattern},
+	}
+	for _, f := range stringFields {
+		if v := q.Get(f.name); v != "" {
+			*f.dst = v
+		}
+	}
+
+	return o, nil
+}
+
+// rateLimit caps total throughput across all clients to perSecond requests,
+// using a token bucket of perSecond tokens refilled one token every
+// 1/perSecond of a second.
+func rateLimit(perSecond int, next http.Handler) http.Handler {
+	tokens := make(chan struct{}, perSecond)
+	for i := 0; i < perSecond; i++ {
+		tokens <- struct{}{}
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(perSecond))
+	go func() {
+		for range ticker.C {
+			select {
+			case tokens <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-tokens:
+			next.ServeHTTP(w, r)
+		default:
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		}
+	})
+}
+
+// statusRecorder wraps a ResponseWriter to capture the status code written,
+// for structured request logging.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		log.Printf("method=%s path=%s status=%d duration=%s", r.Method, r.URL.Path, rec.status, time.Since(start))
+	})
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jokruger/pwgen/internal/generator"
+)
+
+func TestHealthz(t *testing.T) {
+	ts := httptest.NewServer(New(Options{}).Handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("GET /healthz: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestGenerateGet(t *testing.T) {
+	ts := httptest.NewServer(New(Options{}).Handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/generate?format=generic&length=10")
+	if err != nil {
+		t.Fatalf("GET /generate: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var out generateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got := len([]rune(out.Password)); got != 10 {
+		t.Errorf("password length = %d, want 10", got)
+	}
+	if out.Format != "generic" {
+		t.Errorf("format = %q, want %q", out.Format, "generic")
+	}
+}
+
+func TestGeneratePost(t *testing.T) {
+	ts := httptest.NewServer(New(Options{}).Handler)
+	defer ts.Close()
+
+	body, err := json.Marshal(generator.Options{
+		Format:    generator.FormatGeneric,
+		Length:    20,
+		UseLower:  true,
+		UseUpper:  true,
+		UseNumber: true,
+		UseSymbol: true,
+	})
+	if err != nil {
+		t.Fatalf("marshaling request body: %v", err)
+	}
+
+	resp, err := http.Post(ts.URL+"/generate", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /generate: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var out generateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got := len([]rune(out.Password)); got != 20 {
+		t.Errorf("password length = %d, want 20", got)
+	}
+}
+
+func TestGenerateBulk(t *testing.T) {
+	ts := httptest.NewServer(New(Options{}).Handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/generate?format=generic&length=8&bulk=3")
+	if err != nil {
+		t.Fatalf("GET /generate: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var out []generateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(out) != 3 {
+		t.Fatalf("got %d results, want 3", len(out))
+	}
+}
+
+func TestGenerateMethodNotAllowed(t *testing.T) {
+	ts := httptest.NewServer(New(Options{}).Handler)
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodPut, ts.URL+"/generate", nil)
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("PUT /generate: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestGeneratePostIgnoresWordlistFile(t *testing.T) {
+	ts := httptest.NewServer(New(Options{}).Handler)
+	defer ts.Close()
+
+	leakPath := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(leakPath, []byte("super-secret-line\n"), 0o600); err != nil {
+		t.Fatalf("writing fixture file: %v", err)
+	}
+
+	body, err := json.Marshal(generator.Options{
+		Format:       generator.FormatPassphrase,
+		WordCount:    6,
+		WordlistFile: leakPath,
+	})
+	if err != nil {
+		t.Fatalf("marshaling request body: %v", err)
+	}
+
+	resp, err := http.Post(ts.URL+"/generate", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /generate: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var out generateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if strings.Contains(out.Password, "super-secret-line") {
+		t.Fatalf("password leaked requested WordlistFile contents: %q", out.Password)
+	}
+}
+
+func TestGenerateGetPassphrase(t *testing.T) {
+	ts := httptest.NewServer(New(Options{}).Handler)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/generate?format=passphrase&words=4&separator=_")
+	if err != nil {
+		t.Fatalf("GET /generate: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var out generateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got := strings.Count(out.Password, "_"); got != 3 {
+		t.Errorf("separator count = %d, want 3 (got %q)", got, out.Password)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ts := httptest.NewServer(New(Options{RateLimit: 1}).Handler)
+	defer ts.Close()
+
+	first, err := http.Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("first GET /healthz: %v", err)
+	}
+	first.Body.Close()
+	if first.StatusCode != http.StatusOK {
+		t.Fatalf("first request status = %d, want %d", first.StatusCode, http.StatusOK)
+	}
+
+	second, err := http.Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("second GET /healthz: %v", err)
+	}
+	second.Body.Close()
+	if second.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("second request status = %d, want %d", second.StatusCode, http.StatusTooManyRequests)
+	}
+}